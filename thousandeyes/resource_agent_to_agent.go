@@ -32,6 +32,7 @@ func resourceAgentToAgent() *schema.Resource {
 		Description: "This resource allows you to create and configure an agent-to-agent test. This test type evaluates the performance of the underlying network between two physical sites. For more information about agent-to-agent tests, see [Agent-to-Agent Tests](https://docs.thousandeyes.com/product-documentation/internet-and-wan-monitoring/tests#agent-to-agent-test).",
 	}
 	resource.Schema["protocol"] = schemas["protocol-agent_to_agent"]
+	resource.Schema["agent_hostnames"] = schemas["agent_hostnames"]
 	return &resource
 }
 
@@ -47,6 +48,9 @@ func resourceAgentAgentUpdate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[INFO] Updating ThousandEyes Test %s", d.Id())
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	update := ResourceUpdate(d, &thousandeyes.AgentAgent{}).(*thousandeyes.AgentAgent)
+	if err := resolveAgentHostnames(d, client, update); err != nil {
+		return err
+	}
 	_, err := client.UpdateAgentAgent(id, *update)
 	if err != nil {
 		return err
@@ -70,6 +74,9 @@ func resourceAgentAgentCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*thousandeyes.Client)
 	log.Printf("[INFO] Creating ThousandEyes Test %s", d.Id())
 	local := buildAgentAgentStruct(d)
+	if err := resolveAgentHostnames(d, client, local); err != nil {
+		return err
+	}
 	remote, err := client.CreateAgentAgent(*local)
 	if err != nil {
 		return err
@@ -35,6 +35,7 @@ func resourceAgentToServer() *schema.Resource {
 		},
 		Description: "This resource allows you to create and configure an agent-to-server test. This test type measures network performance as seen from ThousandEyes agent(s) towards a remote server. For more information about agent-to-server tests, see [Agent-to-Server Tests](https://docs.thousandeyes.com/product-documentation/internet-and-wan-monitoring/tests#agent-to-server-test).",
 	}
+	resource.Schema["agent_hostnames"] = schemas["agent_hostnames"]
 	return &resource
 }
 
@@ -50,6 +51,9 @@ func resourceAgentServerUpdate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[INFO] Updating ThousandEyes Test %s", d.Id())
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	update := ResourceUpdate(d, &thousandeyes.AgentServer{}).(*thousandeyes.AgentServer)
+	if err := resolveAgentHostnames(d, client, update); err != nil {
+		return err
+	}
 	_, err := client.UpdateAgentServer(id, *update)
 	if err != nil {
 		return err
@@ -73,6 +77,9 @@ func resourceAgentServerCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*thousandeyes.Client)
 	log.Printf("[INFO] Creating ThousandEyes Test %s", d.Id())
 	local := buildAgentServerStruct(d)
+	if err := resolveAgentHostnames(d, client, local); err != nil {
+		return err
+	}
 	remote, err := client.CreateAgentServer(*local)
 	if err != nil {
 		return err
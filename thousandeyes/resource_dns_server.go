@@ -20,6 +20,7 @@ func resourceDNSServer() *schema.Resource {
 		},
 		Description: "This resource allows users to create a DNS server test. This test type validates DNS records and provides service performance metrics. For more information, see [DNS Server Tests](https://docs.thousandeyes.com/product-documentation/internet-and-wan-monitoring/tests#dns-server-test).",
 	}
+	resource.Schema["agent_hostnames"] = schemas["agent_hostnames"]
 	return &resource
 }
 
@@ -35,6 +36,9 @@ func resourceDNSServerUpdate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[INFO] Updating ThousandEyes Test %s", d.Id())
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	update := ResourceUpdate(d, &thousandeyes.DNSServer{}).(*thousandeyes.DNSServer)
+	if err := resolveAgentHostnames(d, client, update); err != nil {
+		return err
+	}
 	_, err := client.UpdateDNSServer(id, *update)
 	if err != nil {
 		return err
@@ -58,6 +62,9 @@ func resourceDNSServerCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*thousandeyes.Client)
 	log.Printf("[INFO] Creating ThousandEyes Test %s", d.Id())
 	local := buildDNSServerStruct(d)
+	if err := resolveAgentHostnames(d, client, local); err != nil {
+		return err
+	}
 	remote, err := client.CreateDNSServer(*local)
 	if err != nil {
 		return err
@@ -20,6 +20,7 @@ func resourceDNSTrace() *schema.Resource {
 		},
 		Description: "This resource provides users with the ability to create a DNS trace test. This test type verifies the delegation of DNS records and ensures the DNS hierarchy is as expected. For more information, see [DNS Trace Tests](https://docs.thousandeyes.com/product-documentation/internet-and-wan-monitoring/tests#dns-trace-test).",
 	}
+	resource.Schema["agent_hostnames"] = schemas["agent_hostnames"]
 	return &resource
 }
 
@@ -35,6 +36,9 @@ func resourceDNSTraceUpdate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[INFO] Updating ThousandEyes Test %s", d.Id())
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	update := ResourceUpdate(d, &thousandeyes.DNSTrace{}).(*thousandeyes.DNSTrace)
+	if err := resolveAgentHostnames(d, client, update); err != nil {
+		return err
+	}
 	_, err := client.UpdateDNSTrace(id, *update)
 	if err != nil {
 		return err
@@ -58,6 +62,9 @@ func resourceDNSTraceCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*thousandeyes.Client)
 	log.Printf("[INFO] Creating ThousandEyes Test %s", d.Id())
 	local := buildDNSTraceStruct(d)
+	if err := resolveAgentHostnames(d, client, local); err != nil {
+		return err
+	}
 	remote, err := client.CreateDNSTrace(*local)
 	if err != nil {
 		return err
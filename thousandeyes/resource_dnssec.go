@@ -20,6 +20,7 @@ func resourceDNSSec() *schema.Resource {
 		},
 		Description: "This resource allows you to create a DNSSEC test. This test type verifies the digital signature of DNS resource records and validates the authenticity of those records. For more information, see [DNSSEC Test](https://docs.thousandeyes.com/product-documentation/internet-and-wan-monitoring/tests#dnssec-test).",
 	}
+	resource.Schema["agent_hostnames"] = schemas["agent_hostnames"]
 	return &resource
 }
 
@@ -35,6 +36,9 @@ func resourceDNSSecUpdate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[INFO] Updating ThousandEyes Test %s", d.Id())
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	update := ResourceUpdate(d, &thousandeyes.DNSSec{}).(*thousandeyes.DNSSec)
+	if err := resolveAgentHostnames(d, client, update); err != nil {
+		return err
+	}
 	_, err := client.UpdateDNSSec(id, *update)
 	if err != nil {
 		return err
@@ -58,6 +62,9 @@ func resourceDNSSecCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*thousandeyes.Client)
 	log.Printf("[INFO] Creating ThousandEyes Test %s", d.Id())
 	local := buildDNSSecStruct(d)
+	if err := resolveAgentHostnames(d, client, local); err != nil {
+		return err
+	}
 	remote, err := client.CreateDNSSec(*local)
 	if err != nil {
 		return err
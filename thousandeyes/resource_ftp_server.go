@@ -22,6 +22,7 @@ func resourceFTPServer() *schema.Resource {
 	}
 	resource.Schema["password"] = schemas["password-ftp"]
 	resource.Schema["username"] = schemas["username-ftp"]
+	resource.Schema["agent_hostnames"] = schemas["agent_hostnames"]
 	return &resource
 }
 
@@ -37,6 +38,9 @@ func resourceFTPServerUpdate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[INFO] Updating ThousandEyes Test %s", d.Id())
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	update := ResourceUpdate(d, &thousandeyes.FTPServer{}).(*thousandeyes.FTPServer)
+	if err := resolveAgentHostnames(d, client, update); err != nil {
+		return err
+	}
 	_, err := client.UpdateFTPServer(id, *update)
 	if err != nil {
 		return err
@@ -60,6 +64,9 @@ func resourceFTPServerCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*thousandeyes.Client)
 	log.Printf("[INFO] Creating ThousandEyes Test %s", d.Id())
 	local := buildFTPServerStruct(d)
+	if err := resolveAgentHostnames(d, client, local); err != nil {
+		return err
+	}
 	remote, err := client.CreateFTPServer(*local)
 	if err != nil {
 		return err
@@ -20,6 +20,7 @@ func resourceSIPServer() *schema.Resource {
 		},
 		Description: "This resource allows you to create a SIP server test. This test type checks for the availability and performance of a VoIP SIP server, confirms the ability to perform SIP Register with a target server, and observes the requests and responses. For more information, see [SIP Server Tests](https://docs.thousandeyes.com/product-documentation/internet-and-wan-monitoring/tests#sip-server-test).",
 	}
+	resource.Schema["agent_hostnames"] = schemas["agent_hostnames"]
 	return &resource
 }
 
@@ -35,6 +36,9 @@ func resourceSIPServerUpdate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[INFO] Updating ThousandEyes Test %s", d.Id())
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	update := ResourceUpdate(d, &thousandeyes.SIPServer{}).(*thousandeyes.SIPServer)
+	if err := resolveAgentHostnames(d, client, update); err != nil {
+		return err
+	}
 	// While most ThousandEyes updates only require updated fields and specifically
 	// disallow some fields on update, SIP Server tests actually require a few fields
 	// within the targetSipCredentials object to be retained on update.
@@ -67,6 +71,9 @@ func resourceSIPServerCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*thousandeyes.Client)
 	log.Printf("[INFO] Creating ThousandEyes Test %s", d.Id())
 	local := buildSIPServerStruct(d)
+	if err := resolveAgentHostnames(d, client, local); err != nil {
+		return err
+	}
 	remote, err := client.CreateSIPServer(*local)
 	if err != nil {
 		return err
@@ -45,10 +45,20 @@ var schemas = map[string]*schema.Schema{
 			},
 		},
 	},
+	"agent_hostnames": {
+		Type:         schema.TypeSet,
+		Description:  "The list of ThousandEyes agent hostnames to use. Each hostname is resolved to a single agent ID and merged with any agents listed in `agents`.",
+		Optional:     true,
+		AtLeastOneOf: []string{"agents", "agent_hostnames"},
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	},
 	"agents": {
-		Type:        schema.TypeSet,
-		Description: "The list of ThousandEyes agents to use.",
-		Required:    true,
+		Type:         schema.TypeSet,
+		Description:  "The list of ThousandEyes agents to use. At least one of `agents` or `agent_hostnames` must be set.",
+		Optional:     true,
+		AtLeastOneOf: []string{"agents", "agent_hostnames"},
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"agent_id": {
@@ -2,10 +2,13 @@ package thousandeyes
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -38,6 +41,170 @@ func expandAgents(v interface{}) thousandeyes.Agents {
 	return agents
 }
 
+// agentsCache holds the agent list fetched for each client, so that
+// hostname lookups made by every resource in a single run only list
+// agents once.
+var agentsCache = struct {
+	sync.Mutex
+	agents map[*thousandeyes.Client]*thousandeyes.Agents
+}{agents: map[*thousandeyes.Client]*thousandeyes.Agents{}}
+
+func getAgentsCached(client *thousandeyes.Client) (*thousandeyes.Agents, error) {
+	agentsCache.Lock()
+	defer agentsCache.Unlock()
+
+	if agents, ok := agentsCache.agents[client]; ok {
+		return agents, nil
+	}
+	agents, err := client.GetAgents()
+	if err != nil {
+		return nil, err
+	}
+	agentsCache.agents[client] = agents
+	return agents, nil
+}
+
+// agentIDByHostname returns the ID of the single agent in the provided list
+// reporting the provided hostname.
+func agentIDByHostname(agents *thousandeyes.Agents, hostname string) (int64, error) {
+	var matches []int64
+	for _, agent := range *agents {
+		if agent.AgentID != nil && agent.Hostname != nil && strings.EqualFold(*agent.Hostname, hostname) {
+			matches = append(matches, *agent.AgentID)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("unable to locate any agent with the hostname: %s", hostname)
+	case 1:
+		return matches[0], nil
+	default:
+		return 0, fmt.Errorf("hostname %s matches multiple agents: %v", hostname, matches)
+	}
+}
+
+// agentIDsByHostname maps each of the provided hostnames to the ID of the
+// single agent reporting that hostname.
+func agentIDsByHostname(client *thousandeyes.Client, hostnames []string) (map[string]int64, error) {
+	agents, err := getAgentsCached(client)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := map[string]int64{}
+	for _, hostname := range hostnames {
+		id, err := agentIDByHostname(agents, hostname)
+		if err != nil {
+			return nil, err
+		}
+		ids[hostname] = id
+	}
+	return ids, nil
+}
+
+// resolveAgentHostnames merges the agents referenced in agent_hostnames
+// into the Agents field of the struct at the provided pointer, alongside
+// any agents listed by ID in agents.
+func resolveAgentHostnames(d *schema.ResourceData, client *thousandeyes.Client, structPtr interface{}) error {
+	hostnames := d.Get("agent_hostnames").(*schema.Set)
+	// Agents listed by ID are already filled in by ResourceBuildStruct and
+	// ResourceUpdate, so there is only work to do when the full list has
+	// to be sent again.
+	if !d.HasChange("agent_hostnames") && (hostnames.Len() == 0 || !d.HasChange("agents")) {
+		return nil
+	}
+
+	names := []string{}
+	for _, v := range hostnames.List() {
+		names = append(names, v.(string))
+	}
+	sort.Strings(names)
+	ids, err := agentIDsByHostname(client, names)
+	if err != nil {
+		return err
+	}
+
+	field := reflect.ValueOf(structPtr).Elem().FieldByName("Agents")
+	agents := FillValue(d.Get("agents"), field.Interface()).(*[]thousandeyes.Agent)
+	seen := map[int64]bool{}
+	for _, agent := range *agents {
+		seen[*agent.AgentID] = true
+	}
+	for _, name := range names {
+		if !seen[ids[name]] {
+			*agents = append(*agents, thousandeyes.Agent{AgentID: thousandeyes.Int64(ids[name])})
+			seen[ids[name]] = true
+		}
+	}
+	field.Set(reflect.ValueOf(agents))
+	return nil
+}
+
+// stripResolvedAgents removes the agents added through agent_hostnames from
+// the Agents field of the struct at the provided pointer, so that they are
+// not also saved to state under agents. Hostnames whose agent is missing
+// from the struct are dropped from agent_hostnames in state, so the next
+// plan adds the agent back.
+func stripResolvedAgents(d *schema.ResourceData, client *thousandeyes.Client, structPtr interface{}) error {
+	field := reflect.ValueOf(structPtr).Elem().FieldByName("Agents")
+	if !field.IsValid() || field.IsNil() {
+		return nil
+	}
+	hostnames := d.Get("agent_hostnames").(*schema.Set)
+	if hostnames.Len() == 0 {
+		return nil
+	}
+
+	names := []string{}
+	for _, v := range hostnames.List() {
+		names = append(names, v.(string))
+	}
+	sort.Strings(names)
+	agents, err := getAgentsCached(client)
+	if err != nil {
+		// Leave the agents as returned; the next apply reports the error.
+		log.Printf("[WARN] Unable to list agents to resolve agent hostnames: %v", err)
+		return nil
+	}
+
+	present := map[int64]bool{}
+	for _, agent := range *field.Interface().(*[]thousandeyes.Agent) {
+		if agent.AgentID != nil {
+			present[*agent.AgentID] = true
+		}
+	}
+
+	keptNames := []interface{}{}
+	resolved := map[int64]bool{}
+	for _, name := range names {
+		id, err := agentIDByHostname(agents, name)
+		if err != nil {
+			// Keep the hostname as configured; the next apply reports the error.
+			log.Printf("[WARN] Unable to resolve agent hostname: %v", err)
+			keptNames = append(keptNames, name)
+			continue
+		}
+		if present[id] {
+			keptNames = append(keptNames, name)
+			resolved[id] = true
+		}
+	}
+	// Agents listed both by ID and by hostname stay under agents.
+	for _, v := range d.Get("agents").(*schema.Set).List() {
+		delete(resolved, int64(v.(map[string]interface{})["agent_id"].(int)))
+	}
+
+	kept := []thousandeyes.Agent{}
+	for _, agent := range *field.Interface().(*[]thousandeyes.Agent) {
+		if agent.AgentID != nil && resolved[*agent.AgentID] {
+			continue
+		}
+		kept = append(kept, agent)
+	}
+	field.Set(reflect.ValueOf(&kept))
+	return d.Set("agent_hostnames", keptNames)
+}
+
 func expandAlertRules(alertRules *[]thousandeyes.AlertRule) *[]thousandeyes.AlertRule {
 	if alertRules == nil {
 		return nil
@@ -94,6 +261,11 @@ func GetResource(d *schema.ResourceData, m interface{}, readFunc ResourceReadFun
 		return err
 	}
 
+	err = stripResolvedAgents(d, client, remote)
+	if err != nil {
+		return err
+	}
+
 	// Continue with updating the state
 	err = ResourceRead(d, remote)
 	if err != nil {
@@ -1,7 +1,12 @@
 package thousandeyes
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -459,6 +464,31 @@ func TestResourceSchemaBuild(t *testing.T) {
 	}
 }
 
+func TestResourceSchemaBuildIntervalValidation(t *testing.T) {
+	schm := ResourceSchemaBuild(thousandeyes.HTTPServer{}, schemas, nil)
+	interval, ok := schm["interval"]
+	if !ok {
+		t.Fatal("Key interval missing from generated schema")
+	}
+
+	for _, v := range []int{60, 120, 300, 600, 900, 1800, 3600} {
+		if _, errs := interval.ValidateFunc(v, "interval"); len(errs) != 0 {
+			t.Errorf("Valid interval %d returned errors: %v", v, errs)
+		}
+	}
+
+	for _, v := range []int{0, 45, 90, 7200} {
+		_, errs := interval.ValidateFunc(v, "interval")
+		if len(errs) != 1 {
+			t.Errorf("Invalid interval %d returned %d errors, expected 1", v, len(errs))
+			continue
+		}
+		if !strings.Contains(errs[0].Error(), strconv.Itoa(v)) || !strings.Contains(errs[0].Error(), "3600") {
+			t.Errorf("Error for invalid interval %d does not name the value and allowed set: %s", v, errs[0])
+		}
+	}
+}
+
 func TestFillValue(t *testing.T) {
 	type refStruct struct {
 		FieldName string `json:"fieldName"`
@@ -601,3 +631,248 @@ func TestGetJSONKey(t *testing.T) {
 		t.Errorf("Field name should be 'testField', but received '%s'", tag)
 	}
 }
+
+func newAgentsTestClient(t *testing.T, requests *int) *thousandeyes.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.URL.Path != "/agents.json" {
+			t.Errorf("Unexpected request path: %s", r.URL.Path)
+		}
+		w.Header().Set("content-type", "application/json")
+		w.Write([]byte(`{"agents": [
+			{"agentId": 1, "agentName": "one", "hostname": "one.example.com"},
+			{"agentId": 2, "agentName": "two", "hostname": "two.example.com"},
+			{"agentId": 3, "agentName": "dup-a", "hostname": "dup.example.com"},
+			{"agentId": 4, "agentName": "dup-b", "hostname": "dup.example.com"},
+			{"agentId": 5, "agentName": "cloud"}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+	return thousandeyes.NewClient(&thousandeyes.ClientOptions{APIEndpoint: server.URL})
+}
+
+func TestAgentIDsByHostname(t *testing.T) {
+	requests := 0
+	client := newAgentsTestClient(t, &requests)
+
+	ids, err := agentIDsByHostname(client, []string{"one.example.com", "TWO.example.com"})
+	if err != nil {
+		t.Fatalf("Resolving hostnames returned error: %s", err.Error())
+	}
+	expected := map[string]int64{"one.example.com": 1, "TWO.example.com": 2}
+	if reflect.DeepEqual(ids, expected) != true {
+		t.Errorf("Hostnames not resolved correctly: Received %#v Expected %#v", ids, expected)
+	}
+
+	_, err = agentIDsByHostname(client, []string{"missing.example.com"})
+	if err == nil || !strings.Contains(err.Error(), "missing.example.com") {
+		t.Errorf("Unknown hostname did not return a matching error: %v", err)
+	}
+
+	_, err = agentIDsByHostname(client, []string{"dup.example.com"})
+	if err == nil || !strings.Contains(err.Error(), "multiple agents") || !strings.Contains(err.Error(), "[3 4]") {
+		t.Errorf("Ambiguous hostname did not return a matching error: %v", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("Agents were listed %d times, expected 1", requests)
+	}
+}
+
+func TestResolveAgentHostnames(t *testing.T) {
+	requests := 0
+	client := newAgentsTestClient(t, &requests)
+	d := schema.TestResourceDataRaw(t, resourceHTTPServer().Schema, map[string]interface{}{
+		"agents": []interface{}{
+			map[string]interface{}{"agent_id": 1},
+		},
+		"agent_hostnames": []interface{}{"one.example.com", "two.example.com"},
+	})
+
+	local := buildHTTPServerStruct(d)
+	if err := resolveAgentHostnames(d, client, local); err != nil {
+		t.Fatalf("Resolving hostnames returned error: %s", err.Error())
+	}
+	var ids []int64
+	for _, agent := range *local.Agents {
+		ids = append(ids, *agent.AgentID)
+	}
+	if reflect.DeepEqual(ids, []int64{1, 2}) != true {
+		t.Errorf("Agents not merged correctly: Received %v Expected %v", ids, []int64{1, 2})
+	}
+
+	// Agents added by hostname are not saved to state under agents.
+	remote := &thousandeyes.HTTPServer{
+		Agents: &[]thousandeyes.Agent{
+			{AgentID: thousandeyes.Int64(1)},
+			{AgentID: thousandeyes.Int64(2)},
+		},
+	}
+	if err := stripResolvedAgents(d, client, remote); err != nil {
+		t.Fatalf("Stripping resolved agents returned error: %s", err.Error())
+	}
+	if len(*remote.Agents) != 1 || *(*remote.Agents)[0].AgentID != 1 {
+		t.Errorf("Agents added by hostname were not stripped: Received %+v", *remote.Agents)
+	}
+}
+
+// getUpdateData returns resource data for an update from the prior
+// attributes to the provided config, so that only differing attributes
+// are reported as changed.
+func getUpdateData(t *testing.T, r *schema.Resource, prior map[string]interface{}, config map[string]interface{}) *schema.ResourceData {
+	priorData := schema.TestResourceDataRaw(t, r.Schema, prior)
+	priorData.SetId("1")
+	state := priorData.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("Error computing diff: %s", err.Error())
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("Error building resource data: %s", err.Error())
+	}
+	return d
+}
+
+func TestResolveAgentHostnamesUpdate(t *testing.T) {
+	prior := map[string]interface{}{
+		"test_name": "before",
+		"agents": []interface{}{
+			map[string]interface{}{"agent_id": 1},
+		},
+		"agent_hostnames": []interface{}{"two.example.com"},
+	}
+	var testCases = []struct {
+		name     string
+		config   map[string]interface{}
+		expected []int64
+		requests int
+	}{
+		{
+			name: "unrelated change",
+			config: map[string]interface{}{
+				"test_name": "after",
+				"agents": []interface{}{
+					map[string]interface{}{"agent_id": 1},
+				},
+				"agent_hostnames": []interface{}{"two.example.com"},
+			},
+			expected: nil,
+			requests: 0,
+		},
+		{
+			name: "agents change",
+			config: map[string]interface{}{
+				"test_name": "before",
+				"agents": []interface{}{
+					map[string]interface{}{"agent_id": 5},
+				},
+				"agent_hostnames": []interface{}{"two.example.com"},
+			},
+			expected: []int64{5, 2},
+			requests: 1,
+		},
+		{
+			name: "agent_hostnames emptied",
+			config: map[string]interface{}{
+				"test_name": "before",
+				"agents": []interface{}{
+					map[string]interface{}{"agent_id": 1},
+				},
+			},
+			expected: []int64{1},
+			requests: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			client := newAgentsTestClient(t, &requests)
+			d := getUpdateData(t, resourceHTTPServer(), prior, tc.config)
+
+			update := ResourceUpdate(d, &thousandeyes.HTTPServer{}).(*thousandeyes.HTTPServer)
+			if err := resolveAgentHostnames(d, client, update); err != nil {
+				t.Fatalf("Resolving hostnames returned error: %s", err.Error())
+			}
+			if requests != tc.requests {
+				t.Errorf("Agents were listed %d times, expected %d", requests, tc.requests)
+			}
+			var ids []int64
+			if update.Agents != nil {
+				for _, agent := range *update.Agents {
+					ids = append(ids, *agent.AgentID)
+				}
+			}
+			if reflect.DeepEqual(ids, tc.expected) != true {
+				t.Errorf("Agents not sent correctly: Received %v Expected %v", ids, tc.expected)
+			}
+		})
+	}
+}
+
+func TestStripResolvedAgentsDrift(t *testing.T) {
+	requests := 0
+	client := newAgentsTestClient(t, &requests)
+	r := resourceHTTPServer()
+	config := map[string]interface{}{
+		"agents": []interface{}{
+			map[string]interface{}{"agent_id": 5},
+		},
+		"agent_hostnames": []interface{}{"one.example.com", "two.example.com"},
+	}
+	d := getUpdateData(t, r, config, config)
+
+	// Agent 2 (two.example.com) was removed from the test outside Terraform.
+	remote := &thousandeyes.HTTPServer{
+		Agents: &[]thousandeyes.Agent{
+			{AgentID: thousandeyes.Int64(1)},
+			{AgentID: thousandeyes.Int64(5)},
+		},
+	}
+	if err := stripResolvedAgents(d, client, remote); err != nil {
+		t.Fatalf("Stripping resolved agents returned error: %s", err.Error())
+	}
+	if len(*remote.Agents) != 1 || *(*remote.Agents)[0].AgentID != 5 {
+		t.Errorf("Agents added by hostname were not stripped: Received %+v", *remote.Agents)
+	}
+	hostnames := d.Get("agent_hostnames").(*schema.Set)
+	if hostnames.Len() != 1 || !hostnames.Contains("one.example.com") {
+		t.Errorf("Hostname of the missing agent was not dropped from state: Received %v", hostnames.List())
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("Error computing diff: %s", err.Error())
+	}
+	if diff == nil || diff.Empty() {
+		t.Error("State matches config even though a hostname-added agent is missing")
+	}
+}
+
+func TestStripResolvedAgentsUnresolvable(t *testing.T) {
+	requests := 0
+	client := newAgentsTestClient(t, &requests)
+	d := schema.TestResourceDataRaw(t, resourceHTTPServer().Schema, map[string]interface{}{
+		"agent_hostnames": []interface{}{"dup.example.com", "one.example.com"},
+	})
+
+	remote := &thousandeyes.HTTPServer{
+		Agents: &[]thousandeyes.Agent{
+			{AgentID: thousandeyes.Int64(1)},
+			{AgentID: thousandeyes.Int64(3)},
+		},
+	}
+	if err := stripResolvedAgents(d, client, remote); err != nil {
+		t.Fatalf("Stripping resolved agents returned error: %s", err.Error())
+	}
+	// dup.example.com is ambiguous, so agent 3 can't be attributed to it,
+	// but one.example.com still resolves and its agent is stripped.
+	if len(*remote.Agents) != 1 || *(*remote.Agents)[0].AgentID != 3 {
+		t.Errorf("Agents not stripped correctly: Received %+v", *remote.Agents)
+	}
+	hostnames := d.Get("agent_hostnames").(*schema.Set)
+	if hostnames.Len() != 2 {
+		t.Errorf("Hostnames not retained in state: Received %v", hostnames.List())
+	}
+}